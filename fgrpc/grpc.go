@@ -0,0 +1,38 @@
+// Copyright 2017 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package fgrpc
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Dial connects to serverAddr, blocking until the connection is up. tls
+// picks a bare TLS handshake against the host's root CAs; pass false and
+// supply a grpc.WithTransportCredentials of your own through opts instead
+// when you need mTLS, a private CA bundle or SNI override. opts is
+// appended after Dial's own defaults, so it can also carry things like
+// grpc.WithPerRPCCredentials.
+func Dial(serverAddr string, tls bool, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	dialOpts := []grpc.DialOption{grpc.WithBlock()}
+	if tls {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(nil)))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithInsecure())
+	}
+	dialOpts = append(dialOpts, opts...)
+	return grpc.Dial(serverAddr, dialOpts...)
+}
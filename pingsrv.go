@@ -20,17 +20,28 @@
 package main
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 
 	"istio.io/fortio/fgrpc"
 	"istio.io/fortio/fnet"
@@ -43,12 +54,188 @@ import (
 // GODEBUG="http2debug=2" GRPC_GO_LOG_VERBOSITY_LEVEL=99 GRPC_GO_LOG_SEVERITY_LEVEL=info grpcping -loglevel debug
 
 var (
-	doHealthFlag  = flag.Bool("health", false, "grpc ping client mode: use health instead of ping")
-	healthSvcFlag = flag.String("healthservice", "", "which service string to pass to health check")
-	payloadFlag   = flag.String("payload", "", "Payload string to send along")
+	doHealthFlag           = flag.Bool("health", false, "grpc ping client mode: use health instead of ping")
+	doHealthWatchFlag      = flag.Bool("healthwatch", false, "grpc ping client mode: use health watch (streaming) instead of check")
+	healthSvcFlag          = flag.String("healthservice", "", "which service string to pass to health check")
+	payloadFlag            = flag.String("payload", "", "Payload string to send along")
+	listFlag               = flag.Bool("list", false, "grpc ping client mode: list services and methods using server reflection")
+	invokeFlag             = flag.String("invoke", "", "grpc ping client mode: invoke the given reflection-discovered method (package.Service/Method) with -payload")
+	streamFlag             = flag.Bool("stream", false, "grpc ping client mode: pipeline requests over a single PingStream instead of unary Ping")
+	setHealthFlag          = flag.String("sethealth", "", "grpc ping client mode: name=STATUS to set on the target's health server via the SetHealth admin RPC")
+	healthWatchTimeoutFlag = flag.Duration("healthwatchtimeout", 30*time.Second,
+		"grpc ping client mode: max duration to wait for -healthwatch status events")
+
+	caCertFlag       = flag.String("cacert", "", "grpc ping client mode: path to a CA bundle (PEM) to verify the server certificate, for private CAs")
+	clientCertFlag   = flag.String("cert", "", "grpc ping client mode: path to a client certificate (PEM) for mTLS")
+	clientKeyFlag    = flag.String("key", "", "grpc ping client mode: path to the client certificate's private key (PEM) for mTLS")
+	serverNameFlag   = flag.String("servername", "", "grpc ping client mode: override the server name used for SNI and certificate verification")
+	perRPCTokenFlag  = flag.String("token", "", "grpc ping client mode: bearer token sent as per-RPC credentials")
+	serverCertFlag   = flag.String("servercert", "", "grpc ping server mode: path to the server certificate (PEM), enables TLS")
+	serverKeyFlag    = flag.String("serverkey", "", "grpc ping server mode: path to the server certificate's private key (PEM)")
+	serverCACertFlag = flag.String("servercacert", "", "grpc ping server mode: path to a CA bundle (PEM) to require and verify client certificates (mTLS)")
 )
 
+// Request chunk0-6 ("wire gRPC binary log capture into
+// pingServer/pingClientCall") is not implemented: grpc-go exposes binary
+// logging only through the GRPC_BINARY_LOG_FILTER environment variable,
+// read once by an internal init() before main runs, with no public
+// Logger/Sink API to install a rule or redirect output to a file
+// programmatically. A -binarylog flag here couldn't actually drive
+// anything, so the request is closed as infeasible against grpc-go's
+// current API rather than shipped as a flag that looks functional but
+// isn't; set GRPC_BINARY_LOG_FILTER in the environment before starting
+// grpcping instead.
+
 type pingSrv struct {
+	healthServer *health.Server
+}
+
+// healthServiceSpec is one name=STATUS pair as passed to -healthservices.
+type healthServiceSpec struct {
+	name   string
+	status grpc_health_v1.HealthCheckResponse_ServingStatus
+}
+
+// healthServiceListFlag accumulates repeated -healthservices name=STATUS
+// flags so pingServer can register several services with distinct initial
+// serving statuses at startup.
+type healthServiceListFlag []healthServiceSpec
+
+func (h *healthServiceListFlag) String() string {
+	return fmt.Sprintf("%v", []healthServiceSpec(*h))
+}
+
+func (h *healthServiceListFlag) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid -healthservices entry %q, want name=STATUS", value)
+	}
+	status, err := parseServingStatus(parts[1])
+	if err != nil {
+		return fmt.Errorf("invalid -healthservices status for %q: %v", parts[0], err)
+	}
+	*h = append(*h, healthServiceSpec{name: parts[0], status: status})
+	return nil
+}
+
+// parseServingStatus maps a ServingStatus name (SERVING, NOT_SERVING,
+// SERVICE_UNKNOWN, ...) to its enum value.
+func parseServingStatus(status string) (grpc_health_v1.HealthCheckResponse_ServingStatus, error) {
+	v, ok := grpc_health_v1.HealthCheckResponse_ServingStatus_value[status]
+	if !ok {
+		return 0, fmt.Errorf("unknown serving status %q", status)
+	}
+	return grpc_health_v1.HealthCheckResponse_ServingStatus(v), nil
+}
+
+// Named -healthservices (plural), not -healthservice: the singular flag
+// already exists above as the client-side Check/Watch target service name.
+var healthServicesFlag healthServiceListFlag
+
+func init() {
+	flag.Var(&healthServicesFlag, "healthservices", "grpc server mode: repeatable name=STATUS to register additional "+
+		"health services at startup (STATUS one of SERVING, NOT_SERVING, SERVICE_UNKNOWN)")
+}
+
+// rawCodec is a grpc encoding.Codec that passes payloads through as raw
+// bytes instead of marshaling/unmarshaling proto messages. It lets
+// grpcInvoke call arbitrary unary methods discovered through reflection
+// without knowing (or generating) their actual request/response types.
+type rawCodec struct{}
+
+type rawBytes []byte
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	b, ok := v.(*rawBytes)
+	if !ok {
+		return nil, fmt.Errorf("rawCodec: unsupported type %T", v)
+	}
+	return *b, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	b, ok := v.(*rawBytes)
+	if !ok {
+		return fmt.Errorf("rawCodec: unsupported type %T", v)
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+func (rawCodec) Name() string { return "raw" }
+
+func init() {
+	encoding.RegisterCodec(rawCodec{})
+}
+
+// tokenCreds is a minimal grpc/credentials.PerRPCCredentials that attaches
+// a static bearer token to every RPC, for servers that authorize on a
+// per-RPC token rather than (or in addition to) the TLS handshake.
+type tokenCreds struct {
+	token string
+}
+
+func (t tokenCreds) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+func (t tokenCreds) RequireTransportSecurity() bool { return true }
+
+// buildTLSConfig assembles a crypto/tls.Config from the -cacert, -cert,
+// -key and -servername flags.
+func buildTLSConfig() (*tls.Config, error) {
+	cfg := &tls.Config{}
+	if *serverNameFlag != "" {
+		cfg.ServerName = *serverNameFlag
+	}
+	if *caCertFlag != "" {
+		pemData, err := ioutil.ReadFile(*caCertFlag)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read -cacert %q: %v", *caCertFlag, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pemData) {
+			return nil, fmt.Errorf("unable to parse CA bundle %q", *caCertFlag)
+		}
+		cfg.RootCAs = pool
+	}
+	if *clientCertFlag != "" || *clientKeyFlag != "" {
+		cert, err := tls.LoadX509KeyPair(*clientCertFlag, *clientKeyFlag)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client cert/key %q/%q: %v", *clientCertFlag, *clientKeyFlag, err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// grpcDial dials serverAddr through fgrpc.Dial (blocking dial, plus bare
+// TLS or insecure transport), appending credentials options on top of it
+// when -cacert, -cert, -key, -servername or -token are set rather than
+// building a separate grpc.Dial from scratch.
+func grpcDial(serverAddr string, secure bool) (*grpc.ClientConn, error) {
+	var opts []grpc.DialOption
+	haveCustomCreds := *caCertFlag != "" || *clientCertFlag != "" || *clientKeyFlag != "" || *serverNameFlag != ""
+	if haveCustomCreds {
+		tlsConfig, err := buildTLSConfig()
+		if err != nil {
+			log.Errf("%v", err)
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+	if *perRPCTokenFlag != "" {
+		if !secure && !haveCustomCreds {
+			err := fmt.Errorf("-token requires transport security: set -grpc-secure, -cacert, -cert/-key or -servername")
+			log.Errf("%v", err)
+			return nil, err
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCreds{token: *perRPCTokenFlag}))
+	}
+	// haveCustomCreds already supplied a grpc.WithTransportCredentials above;
+	// telling fgrpc.Dial tls=true on top of that would conflict with its own
+	// default TLS credentials, so only ask it for bare TLS otherwise.
+	return fgrpc.Dial(serverAddr, secure && !haveCustomCreds, opts...)
 }
 
 func (s *pingSrv) Ping(c context.Context, in *fgrpc.PingMessage) (*fgrpc.PingMessage, error) {
@@ -58,26 +245,81 @@ func (s *pingSrv) Ping(c context.Context, in *fgrpc.PingMessage) (*fgrpc.PingMes
 	return &out, nil
 }
 
+// PingStream is the streaming counterpart of Ping: it echoes back every
+// message received on the stream, stamped with the server's receive time.
+func (s *pingSrv) PingStream(stream fgrpc.PingServer_PingStreamServer) error {
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		log.LogVf("PingStream called %+v", *in)
+		out := *in
+		out.Ts = time.Now().UnixNano()
+		if err := stream.Send(&out); err != nil {
+			return err
+		}
+	}
+}
+
+// SetHealth toggles the serving status of a named service at runtime.
+func (s *pingSrv) SetHealth(c context.Context, in *fgrpc.HealthControlMessage) (*fgrpc.HealthControlMessage, error) {
+	status, err := parseServingStatus(in.Status)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("SetHealth %s -> %s", in.Name, in.Status)
+	s.healthServer.SetServingStatus(in.Name, status)
+	return in, nil
+}
+
 func pingServer(port string) {
 	port = fnet.NormalizePort(port)
 	socket, err := net.Listen("tcp", port)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
-	grpcServer := grpc.NewServer()
+	var serverOpts []grpc.ServerOption
+	if *serverCertFlag != "" {
+		cert, err := tls.LoadX509KeyPair(*serverCertFlag, *serverKeyFlag)
+		if err != nil {
+			log.Fatalf("unable to load -servercert/-serverkey %q/%q: %v", *serverCertFlag, *serverKeyFlag, err)
+		}
+		tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+		if *serverCACertFlag != "" {
+			pemData, err := ioutil.ReadFile(*serverCACertFlag)
+			if err != nil {
+				log.Fatalf("unable to read -servercacert %q: %v", *serverCACertFlag, err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pemData) {
+				log.Fatalf("unable to parse CA bundle %q", *serverCACertFlag)
+			}
+			tlsConfig.ClientCAs = pool
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
 	reflection.Register(grpcServer)
 	healthServer := health.NewServer()
 	healthServer.SetServingStatus("ping", grpc_health_v1.HealthCheckResponse_SERVING)
+	for _, spec := range healthServicesFlag {
+		healthServer.SetServingStatus(spec.name, spec.status)
+	}
 	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
-	fgrpc.RegisterPingServerServer(grpcServer, &pingSrv{})
+	fgrpc.RegisterPingServerServer(grpcServer, &pingSrv{healthServer: healthServer})
 	fmt.Printf("Fortio %s grpc ping server listening on port %v\n", version.Short(), port)
 	if err := grpcServer.Serve(socket); err != nil {
 		log.Fatalf("failed to start grpc server: %v", err)
 	}
 }
 
-func pingClientCall(serverAddr string, tls bool, n int, payload string) {
-	conn, err := fgrpc.Dial(serverAddr, tls)
+func pingClientCall(serverAddr string, secure bool, n int, payload string) {
+	conn, err := grpcDial(serverAddr, secure)
 	if err != nil {
 		os.Exit(1) // error already logged
 	}
@@ -124,8 +366,112 @@ func pingClientCall(serverAddr string, tls bool, n int, payload string) {
 	rttHistogram.Print(os.Stdout, "RTT histogram usec", []float64{50})
 }
 
-func grpcHealthCheck(serverAddr string, tls bool, svcname string, n int) {
-	conn, err := fgrpc.Dial(serverAddr, tls)
+// seqTracker records the send time of in-flight pipelined messages, keyed
+// by sequence number, so a receiver goroutine can compute per-message RTT
+// as responses arrive out of send order.
+type seqTracker struct {
+	mu   sync.Mutex
+	sent map[int64]int64
+}
+
+func newSeqTracker(n int) *seqTracker {
+	return &seqTracker{sent: make(map[int64]int64, n)}
+}
+
+func (t *seqTracker) record(seq int64, sendTs int64) {
+	t.mu.Lock()
+	t.sent[seq] = sendTs
+	t.mu.Unlock()
+}
+
+// complete removes seq from the in-flight set and reports its send time
+// (if known) along with the number of messages still in flight.
+func (t *seqTracker) complete(seq int64) (sendTs int64, remaining int, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sendTs, ok = t.sent[seq]
+	if ok {
+		delete(t.sent, seq)
+	}
+	return sendTs, len(t.sent), ok
+}
+
+// pingClientStreamCall pipelines n requests over a single PingStream,
+// sending them back to back without waiting for the matching response,
+// and measures per-message RTT via seqTracker.
+func pingClientStreamCall(serverAddr string, secure bool, n int, payload string) {
+	conn, err := grpcDial(serverAddr, secure)
+	if err != nil {
+		os.Exit(1) // error already logged
+	}
+	cli := fgrpc.NewPingServerClient(conn)
+	stream, err := cli.PingStream(context.Background())
+	if err != nil {
+		log.Fatalf("grpc error opening PingStream %v", err)
+	}
+	rttHistogram := stats.NewHistogram(0, 10)
+	tracker := newSeqTracker(n)
+	done := make(chan error, 1)
+	go func() {
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				done <- nil
+				return
+			}
+			if err != nil {
+				done <- err
+				return
+			}
+			now := time.Now().UnixNano()
+			sendTs, remaining, ok := tracker.complete(res.Seq)
+			if ok {
+				rttHistogram.Record(float64(now-sendTs) / 1000.)
+			}
+			if res.Seq >= int64(n) && remaining == 0 {
+				done <- nil
+				return
+			}
+		}
+	}()
+	for i := 1; i <= n; i++ {
+		msg := &fgrpc.PingMessage{Seq: int64(i), Payload: payload, Ts: time.Now().UnixNano()}
+		tracker.record(msg.Seq, msg.Ts)
+		if err := stream.Send(msg); err != nil {
+			log.Fatalf("grpc error sending on PingStream %v", err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		log.Fatalf("grpc error closing PingStream %v", err)
+	}
+	if err := <-done; err != nil {
+		log.Fatalf("grpc error receiving on PingStream %v", err)
+	}
+	rttHistogram.Print(os.Stdout, "RTT histogram usec", []float64{50})
+}
+
+// grpcSetHealth calls the SetHealth admin RPC to toggle the serving
+// status of name on the target, given a "name=STATUS" spec.
+func grpcSetHealth(serverAddr string, secure bool, spec string) {
+	parts := strings.SplitN(spec, "=", 2)
+	if len(parts) != 2 {
+		log.Fatalf("invalid -sethealth %q, want name=STATUS", spec)
+	}
+	conn, err := grpcDial(serverAddr, secure)
+	if err != nil {
+		os.Exit(1) // error already logged
+	}
+	cli := fgrpc.NewPingServerClient(conn)
+	req := &fgrpc.HealthControlMessage{Name: parts[0], Status: parts[1]}
+	res, err := cli.SetHealth(context.Background(), req)
+	if err != nil {
+		log.Fatalf("grpc error from SetHealth %v", err)
+	}
+	fmt.Printf("SetHealth %s -> %s ok\n", res.Name, res.Status)
+}
+
+func grpcHealthCheck(serverAddr string, secure bool, svcname string, n int) {
+	conn, err := grpcDial(serverAddr, secure)
 	if err != nil {
 		os.Exit(1) // error already logged
 	}
@@ -148,6 +494,197 @@ func grpcHealthCheck(serverAddr string, tls bool, svcname string, n int) {
 	fmt.Printf("Statuses %v\n", statuses)
 }
 
+// grpcHealthWatch uses the streaming Health.Watch RPC (as opposed to the
+// unary Check used by grpcHealthCheck) to subscribe to serving status
+// changes for svcname. It records the time between status-change events in
+// a histogram, tallies each observed ServingStatus, and returns after n
+// events have been received or the context times out, whichever is first.
+func grpcHealthWatch(serverAddr string, secure bool, svcname string, n int) {
+	conn, err := grpcDial(serverAddr, secure)
+	if err != nil {
+		os.Exit(1) // error already logged
+	}
+	cli := grpc_health_v1.NewHealthClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), *healthWatchTimeoutFlag)
+	defer cancel()
+	stream, err := cli.Watch(ctx, &grpc_health_v1.HealthCheckRequest{Service: svcname})
+	if err != nil {
+		log.Fatalf("grpc error from Watch %v", err)
+	}
+	eventHistogram := stats.NewHistogram(0, 10)
+	statuses := make(map[grpc_health_v1.HealthCheckResponse_ServingStatus]int64)
+	var last time.Time
+	count := 0
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Infof("Watch stream ending: %v", ctx.Err())
+				break
+			}
+			log.Fatalf("grpc error from Watch.Recv %v", err)
+		}
+		now := time.Now()
+		if count > 0 {
+			eventHistogram.Record(now.Sub(last).Seconds() * 1000000.)
+		}
+		last = now
+		statuses[res.Status]++
+		count++
+		if n > 0 && count >= n {
+			break
+		}
+	}
+	eventHistogram.Print(os.Stdout, "Status change interval histogram usec", []float64{50})
+	fmt.Printf("Statuses %v\n", statuses)
+}
+
+// reflectListServices returns the fully-qualified service names registered
+// on the peer, via a single ListServices round trip.
+func reflectListServices(cli grpc_reflection_v1alpha.ServerReflectionClient) ([]string, error) {
+	stream, err := cli.ServerReflectionInfo(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	req := &grpc_reflection_v1alpha.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1alpha.ServerReflectionRequest_ListServices{},
+	}
+	if err := stream.Send(req); err != nil {
+		return nil, err
+	}
+	res, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, svc := range res.GetListServicesResponse().GetService() {
+		names = append(names, svc.Name)
+	}
+	return names, nil
+}
+
+// fullServiceName returns the package-qualified name ServerReflectionInfo
+// uses for svc, matching what ListServices reports.
+func fullServiceName(fd *descpb.FileDescriptorProto, svc *descpb.ServiceDescriptorProto) string {
+	if fd.GetPackage() == "" {
+		return svc.GetName()
+	}
+	return fd.GetPackage() + "." + svc.GetName()
+}
+
+// reflectMethods resolves service's methods by fetching the file that
+// defines it via FileContainingSymbol and parsing the returned
+// FileDescriptorProto, so -list can print full package.Service/Method
+// paths and -invoke can validate a method before calling it.
+func reflectMethods(cli grpc_reflection_v1alpha.ServerReflectionClient, service string) ([]*descpb.MethodDescriptorProto, error) {
+	stream, err := cli.ServerReflectionInfo(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	req := &grpc_reflection_v1alpha.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1alpha.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}
+	if err := stream.Send(req); err != nil {
+		return nil, err
+	}
+	res, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	fdResp := res.GetFileDescriptorResponse()
+	if fdResp == nil {
+		return nil, fmt.Errorf("no file descriptor for service %q", service)
+	}
+	var methods []*descpb.MethodDescriptorProto
+	for _, raw := range fdResp.FileDescriptorProto {
+		fd := &descpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(raw, fd); err != nil {
+			return nil, err
+		}
+		for _, svc := range fd.GetService() {
+			if fullServiceName(fd, svc) == service {
+				methods = append(methods, svc.GetMethod()...)
+			}
+		}
+	}
+	return methods, nil
+}
+
+// grpcList uses the gRPC Server Reflection API to enumerate services and
+// their methods on serverAddr, printing each as package.Service/Method.
+func grpcList(serverAddr string, secure bool) {
+	conn, err := grpcDial(serverAddr, secure)
+	if err != nil {
+		os.Exit(1) // error already logged
+	}
+	cli := grpc_reflection_v1alpha.NewServerReflectionClient(conn)
+	services, err := reflectListServices(cli)
+	if err != nil {
+		log.Fatalf("grpc error listing services %v", err)
+	}
+	for _, service := range services {
+		methods, err := reflectMethods(cli, service)
+		if err != nil {
+			log.Warnf("unable to resolve methods for %s: %v", service, err)
+			continue
+		}
+		for _, m := range methods {
+			fmt.Printf("%s/%s\n", service, m.GetName())
+		}
+	}
+}
+
+// splitMethod splits "package.Service/Method" into its service and method
+// parts.
+func splitMethod(method string) (service string, name string, err error) {
+	i := strings.LastIndex(method, "/")
+	if i < 0 {
+		return "", "", fmt.Errorf("invalid method %q, want package.Service/Method", method)
+	}
+	return method[:i], method[i+1:], nil
+}
+
+// grpcInvoke calls a unary method discovered via reflection (e.g.
+// "package.Service/Method"), after checking via reflectMethods that it
+// exists and isn't streaming, sending payload as the raw request bytes
+// and printing the raw response bytes, timing the round trip.
+func grpcInvoke(serverAddr string, secure bool, method string, payload string) {
+	service, name, err := splitMethod(method)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	conn, err := grpcDial(serverAddr, secure)
+	if err != nil {
+		os.Exit(1) // error already logged
+	}
+	cli := grpc_reflection_v1alpha.NewServerReflectionClient(conn)
+	methods, err := reflectMethods(cli, service)
+	if err != nil {
+		log.Fatalf("grpc reflection lookup for %s failed: %v", service, err)
+	}
+	var found *descpb.MethodDescriptorProto
+	for _, m := range methods {
+		if m.GetName() == name {
+			found = m
+			break
+		}
+	}
+	if found == nil {
+		log.Fatalf("method %q not found on service %q via reflection", name, service)
+	}
+	if found.GetClientStreaming() || found.GetServerStreaming() {
+		log.Fatalf("method %s is streaming, -invoke only supports unary methods", method)
+	}
+	in := rawBytes(payload)
+	out := rawBytes{}
+	start := time.Now()
+	if err := conn.Invoke(context.Background(), method, &in, &out, grpc.CallContentSubtype(rawCodec{}.Name())); err != nil {
+		log.Fatalf("grpc error invoking %s: %v", method, err)
+	}
+	log.Infof("Invoke %s RTT %v, reply %d bytes", method, time.Since(start), len(out))
+	fmt.Printf("%s\n", out)
+}
+
 func grpcClient() {
 	if len(flag.Args()) != 1 {
 		usage("Error: fortio grpcping needs host argument in the form of host, host:port or ip:port")
@@ -157,10 +694,21 @@ func grpcClient() {
 	if count <= 0 {
 		count = 1
 	}
-	tls := *grpcSecureFlag
-	if *doHealthFlag {
-		grpcHealthCheck(host, tls, *healthSvcFlag, count)
-	} else {
-		pingClientCall(host, tls, count, *payloadFlag)
+	secure := *grpcSecureFlag
+	switch {
+	case *listFlag:
+		grpcList(host, secure)
+	case *invokeFlag != "":
+		grpcInvoke(host, secure, *invokeFlag, *payloadFlag)
+	case *setHealthFlag != "":
+		grpcSetHealth(host, secure, *setHealthFlag)
+	case *doHealthWatchFlag:
+		grpcHealthWatch(host, secure, *healthSvcFlag, count)
+	case *doHealthFlag:
+		grpcHealthCheck(host, secure, *healthSvcFlag, count)
+	case *streamFlag:
+		pingClientStreamCall(host, secure, count, *payloadFlag)
+	default:
+		pingClientCall(host, secure, count, *payloadFlag)
 	}
 }
@@ -0,0 +1,158 @@
+// Copyright 2017 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"testing"
+
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestParseServingStatus(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    grpc_health_v1.HealthCheckResponse_ServingStatus
+		wantErr bool
+	}{
+		{"SERVING", grpc_health_v1.HealthCheckResponse_SERVING, false},
+		{"NOT_SERVING", grpc_health_v1.HealthCheckResponse_NOT_SERVING, false},
+		{"SERVICE_UNKNOWN", grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN, false},
+		{"bogus", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseServingStatus(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseServingStatus(%q): expected error, got %v", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseServingStatus(%q): unexpected error %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseServingStatus(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSeqTracker(t *testing.T) {
+	tr := newSeqTracker(2)
+	tr.record(1, 100)
+	tr.record(2, 200)
+	sendTs, remaining, ok := tr.complete(1)
+	if !ok || sendTs != 100 || remaining != 1 {
+		t.Errorf("complete(1) = %d, %d, %v; want 100, 1, true", sendTs, remaining, ok)
+	}
+	if _, _, ok := tr.complete(1); ok {
+		t.Error("complete(1) a second time should report ok=false")
+	}
+	sendTs, remaining, ok = tr.complete(2)
+	if !ok || sendTs != 200 || remaining != 0 {
+		t.Errorf("complete(2) = %d, %d, %v; want 200, 0, true", sendTs, remaining, ok)
+	}
+	if _, _, ok := tr.complete(3); ok {
+		t.Error("complete(3) for an unrecorded seq should report ok=false")
+	}
+}
+
+func TestHealthServiceListFlagSet(t *testing.T) {
+	var f healthServiceListFlag
+	if err := f.Set("ping=SERVING"); err != nil {
+		t.Fatalf("Set(ping=SERVING): unexpected error %v", err)
+	}
+	if err := f.Set("other=NOT_SERVING"); err != nil {
+		t.Fatalf("Set(other=NOT_SERVING): unexpected error %v", err)
+	}
+	if len(f) != 2 {
+		t.Fatalf("expected 2 entries, got %d: %v", len(f), f)
+	}
+	if f[0].name != "ping" || f[0].status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("unexpected first entry %+v", f[0])
+	}
+	if f[1].name != "other" || f[1].status != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("unexpected second entry %+v", f[1])
+	}
+	if err := f.Set("noequals"); err == nil {
+		t.Error("Set(noequals): expected error, got nil")
+	}
+	if err := f.Set("name=BOGUS"); err == nil {
+		t.Error("Set(name=BOGUS): expected error, got nil")
+	}
+}
+
+func TestSplitMethod(t *testing.T) {
+	tests := []struct {
+		in          string
+		wantService string
+		wantName    string
+		wantErr     bool
+	}{
+		{"pkg.Service/Method", "pkg.Service", "Method", false},
+		{"Service/Method", "Service", "Method", false},
+		{"pkg.Service/Method/", "pkg.Service/Method", "", false},
+		{"noslash", "", "", true},
+		{"", "", "", true},
+	}
+	for _, tt := range tests {
+		service, name, err := splitMethod(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("splitMethod(%q): expected error, got service=%q name=%q", tt.in, service, name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("splitMethod(%q): unexpected error %v", tt.in, err)
+			continue
+		}
+		if service != tt.wantService || name != tt.wantName {
+			t.Errorf("splitMethod(%q) = %q, %q; want %q, %q", tt.in, service, name, tt.wantService, tt.wantName)
+		}
+	}
+}
+
+func TestFullServiceName(t *testing.T) {
+	pkg := "pkg"
+	svcName := "Service"
+	tests := []struct {
+		name string
+		fd   *descpb.FileDescriptorProto
+		svc  *descpb.ServiceDescriptorProto
+		want string
+	}{
+		{
+			name: "qualified package",
+			fd:   &descpb.FileDescriptorProto{Package: &pkg},
+			svc:  &descpb.ServiceDescriptorProto{Name: &svcName},
+			want: "pkg.Service",
+		},
+		{
+			name: "empty package",
+			fd:   &descpb.FileDescriptorProto{},
+			svc:  &descpb.ServiceDescriptorProto{Name: &svcName},
+			want: "Service",
+		},
+	}
+	for _, tt := range tests {
+		if got := fullServiceName(tt.fd, tt.svc); got != tt.want {
+			t.Errorf("%s: fullServiceName() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}